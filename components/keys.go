@@ -0,0 +1,34 @@
+package components
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// keyRepeatDelay and keyRepeatInterval control how quickly a held navigation
+// or editing key repeats, matching typical desktop text field behaviour.
+const (
+	keyRepeatDelay    = 30 // ticks before a held key starts repeating
+	keyRepeatInterval = 3  // ticks between repeats once started
+)
+
+// repeatingKeyPressed reports whether key was just pressed, or has been held
+// long enough to trigger a repeat.
+func repeatingKeyPressed(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+	if d == 0 {
+		return false
+	}
+	if d == 1 {
+		return true
+	}
+	if d < keyRepeatDelay {
+		return false
+	}
+	return (d-keyRepeatDelay)%keyRepeatInterval == 0
+}
+
+// inpututilKeyJustPressed reports whether key was pressed this tick.
+func inpututilKeyJustPressed(key ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(key)
+}
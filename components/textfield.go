@@ -0,0 +1,449 @@
+// Package components provides ready to use Component implementations that
+// can be mounted onto a console.Console via AddComponent.
+package components
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/BigJk/ramen/concolor"
+	"github.com/BigJk/ramen/console"
+	"github.com/BigJk/ramen/t"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"golang.design/x/clipboard"
+)
+
+// textFieldSeq assigns each TextField a unique ID, since Console/Simulation
+// key their component map by ID() and a constant would let a second field
+// silently replace the first.
+var textFieldSeq int64
+
+// clipboard.Init panics if the clipboard is used before it succeeds, so it is
+// run lazily, once, the first time any TextField touches the clipboard.
+var (
+	clipboardOnce sync.Once
+	clipboardErr  error
+)
+
+func ensureClipboard() bool {
+	clipboardOnce.Do(func() {
+		clipboardErr = clipboard.Init()
+	})
+	return clipboardErr == nil
+}
+
+// TextField is a single- or multi-line editable text input that integrates
+// with Ebiten's exp/textinput.Field for proper IME and dead-key handling on
+// desktop and mobile.
+type TextField struct {
+	x, y, w, h int
+	multiline  bool
+
+	value    []rune
+	cursor   int
+	selStart int
+	selEnd   int
+
+	focused     bool
+	shouldClose bool
+
+	blinkElapsed float64
+	blinkVisible bool
+
+	field textinput.Field
+
+	onSubmit func(string)
+	onChange func(string)
+
+	fg concolor.Color
+	bg concolor.Color
+
+	id string
+}
+
+// NewTextField creates a new TextField at the given position and size. If
+// multiline is false, Enter triggers OnSubmit instead of inserting a newline.
+func NewTextField(x, y, w, h int, multiline bool) *TextField {
+	return &TextField{
+		x:         x,
+		y:         y,
+		w:         w,
+		h:         h,
+		multiline: multiline,
+		fg:        concolor.RGB(255, 255, 255),
+		bg:        concolor.RGBA(0, 0, 0, 0),
+		id:        fmt.Sprintf("text-field-%d", atomic.AddInt64(&textFieldSeq, 1)),
+	}
+}
+
+// ID returns the unique identifier used by Console/Simulation to track this component.
+func (tf *TextField) ID() string {
+	return tf.id
+}
+
+// Value returns the current text of the field.
+func (tf *TextField) Value() string {
+	return string(tf.value)
+}
+
+// SetValue replaces the current text of the field and moves the cursor to its end.
+func (tf *TextField) SetValue(value string) {
+	tf.value = []rune(value)
+	tf.cursor = len(tf.value)
+	tf.selStart, tf.selEnd = tf.cursor, tf.cursor
+}
+
+// OnSubmit registers a callback that is invoked when the user presses Enter
+// (single-line fields) or Ctrl+Enter (multi-line fields).
+func (tf *TextField) OnSubmit(fn func(string)) {
+	tf.onSubmit = fn
+}
+
+// OnChange registers a callback that is invoked every time the value changes.
+func (tf *TextField) OnChange(fn func(string)) {
+	tf.onChange = fn
+}
+
+// FocusOnClick reports that this component should receive focus when clicked,
+// as expected by Console.propagateComponentUpdates.
+func (tf *TextField) FocusOnClick() bool {
+	return true
+}
+
+// SetFocus sets whether the field currently has keyboard focus.
+func (tf *TextField) SetFocus(focus bool) {
+	if tf.focused == focus {
+		return
+	}
+
+	tf.focused = focus
+	if !focus && tf.field.IsFocused() {
+		tf.field.Blur()
+	}
+}
+
+// Position returns the top-left cell of the field.
+func (tf *TextField) Position() (int, int) {
+	return tf.x, tf.y
+}
+
+// Size returns the width and height of the field in cells.
+func (tf *TextField) Size() (int, int) {
+	return tf.w, tf.h
+}
+
+// ShouldDraw reports whether the field should still be drawn and updated.
+func (tf *TextField) ShouldDraw() bool {
+	return !tf.shouldClose
+}
+
+// ShouldClose reports whether the field requested to be removed from its console.
+func (tf *TextField) ShouldClose() bool {
+	return tf.shouldClose
+}
+
+// Update advances cursor blinking, handles navigation/selection/clipboard
+// keys and feeds the Ebiten textinput.Field so IME composition works. It
+// returns false once the field wants to be removed.
+func (tf *TextField) Update(con console.Renderer, timeElapsed float64) bool {
+	if tf.shouldClose {
+		return false
+	}
+
+	tf.blinkElapsed += timeElapsed
+	if tf.blinkElapsed >= 0.5 {
+		tf.blinkElapsed = 0
+		tf.blinkVisible = !tf.blinkVisible
+	}
+
+	if !tf.focused {
+		return true
+	}
+
+	if !tf.field.IsFocused() {
+		tf.field.Focus()
+		tf.syncFieldFromValue()
+	}
+
+	handled, err := tf.field.HandleInputWithBounds(tf.inputBounds(con))
+	if err == nil && handled {
+		tf.syncValueFromField()
+		return true
+	}
+
+	tf.handleNavigation()
+	tf.handleClipboard()
+	tf.syncFieldFromValue()
+
+	return true
+}
+
+// Draw renders the field's current value, selection highlight and blinking
+// cursor onto con, offset by the parent sub-console's position.
+func (tf *TextField) Draw(con console.Renderer, timeElapsed float64) {
+	_ = con.TransformArea(tf.x, tf.y, tf.w, tf.h, t.BG(tf.bg))
+
+	text := string(tf.value)
+	con.PrintBounded(tf.x, tf.y, tf.w, tf.h, text, t.FG(tf.fg))
+
+	if start, end := tf.selection(); start >= 0 {
+		for pos := start; pos < end; pos++ {
+			cx, cy := tf.cellForRune(pos)
+			_ = con.Transform(tf.x+cx, tf.y+cy, t.BG(tf.fg), t.FG(tf.bg))
+		}
+	}
+
+	if tf.focused && tf.blinkVisible {
+		cx, cy := tf.cellForRune(tf.cursor)
+		_ = con.Transform(tf.x+cx, tf.y+cy, t.BG(tf.fg), t.FG(tf.bg))
+	}
+}
+
+// Close marks the field for removal from its console on the next update.
+func (tf *TextField) Close() {
+	tf.shouldClose = true
+}
+
+func (tf *TextField) cellForRune(pos int) (int, int) {
+	if !tf.multiline {
+		if pos >= tf.w {
+			return tf.w - 1, 0
+		}
+		return pos, 0
+	}
+
+	line, col := 0, 0
+	for i := 0; i < pos && i < len(tf.value); i++ {
+		if tf.value[i] == '\n' {
+			line++
+			col = 0
+		} else {
+			col++
+		}
+	}
+	return col, line
+}
+
+// inputBounds returns where the IME composition window should be anchored,
+// as a one-pixel-wide rectangle at the cursor with the row's full height.
+// Only a real Console has a font and a window to anchor to; a headless
+// Simulation has neither, so it falls back to the origin.
+func (tf *TextField) inputBounds(con console.Renderer) image.Rectangle {
+	c, ok := con.(*console.Console)
+	if !ok {
+		return image.Rect(0, 0, 1, 1)
+	}
+
+	cx, cy := tf.cellForRune(tf.cursor)
+	x := (tf.x + cx) * c.Font.TileWidth
+	y := (tf.y + cy) * c.Font.TileHeight
+	return image.Rect(x, y, x+1, y+c.Font.TileHeight)
+}
+
+// syncFieldFromValue pushes our own edits (navigation, clipboard, ...) into
+// the textinput.Field so the next HandleInputWithBounds call sees an
+// up-to-date buffer to composite IME input against.
+func (tf *TextField) syncFieldFromValue() {
+	start, end := tf.selStart, tf.selEnd
+	if start > end {
+		start, end = end, start
+	}
+	tf.field.SetTextAndSelection(string(tf.value), runeIndexToByteOffset(tf.value, start), runeIndexToByteOffset(tf.value, end))
+}
+
+// syncValueFromField reads back the text and selection committed by the IME
+// via HandleInputWithBounds into tf.value/tf.cursor.
+func (tf *TextField) syncValueFromField() {
+	text := tf.field.Text()
+	tf.value = []rune(text)
+
+	startInBytes, endInBytes := tf.field.Selection()
+	tf.selStart = byteOffsetToRuneIndex(tf.value, startInBytes)
+	tf.selEnd = byteOffsetToRuneIndex(tf.value, endInBytes)
+	tf.cursor = tf.selEnd
+
+	if tf.onChange != nil {
+		tf.onChange(text)
+	}
+}
+
+func runeIndexToByteOffset(value []rune, idx int) int {
+	if idx <= 0 {
+		return 0
+	}
+	if idx >= len(value) {
+		idx = len(value)
+	}
+	return len(string(value[:idx]))
+}
+
+func byteOffsetToRuneIndex(value []rune, offset int) int {
+	var bytes int
+	for i, r := range value {
+		if bytes >= offset {
+			return i
+		}
+		bytes += utf8.RuneLen(r)
+	}
+	return len(value)
+}
+
+// OnMouse focuses the field on a left click and consumes the event.
+func (tf *TextField) OnMouse(event console.MouseEvent) bool {
+	if event.Type != console.MouseClick || event.Button != ebiten.MouseButtonLeft {
+		return false
+	}
+
+	tf.SetFocus(true)
+	return true
+}
+
+func (tf *TextField) insert(text []rune) {
+	if len(text) == 0 {
+		return
+	}
+
+	tf.deleteSelection()
+
+	head := append([]rune{}, tf.value[:tf.cursor]...)
+	tail := append([]rune{}, tf.value[tf.cursor:]...)
+
+	tf.value = append(head, append(text, tail...)...)
+	tf.cursor += len(text)
+	tf.selStart, tf.selEnd = tf.cursor, tf.cursor
+
+	if tf.onChange != nil {
+		tf.onChange(string(tf.value))
+	}
+}
+
+// deleteSelection removes the active selection, if any, and moves the
+// cursor to where it started. Reports whether anything was deleted.
+func (tf *TextField) deleteSelection() bool {
+	start, end := tf.selection()
+	if start < 0 {
+		return false
+	}
+
+	tf.value = append(tf.value[:start], tf.value[end:]...)
+	tf.cursor = start
+	tf.selStart, tf.selEnd = tf.cursor, tf.cursor
+	return true
+}
+
+func (tf *TextField) handleNavigation() {
+	switch {
+	case repeatingKeyPressed(ebiten.KeyLeft):
+		if tf.cursor > 0 {
+			tf.cursor--
+		}
+	case repeatingKeyPressed(ebiten.KeyRight):
+		if tf.cursor < len(tf.value) {
+			tf.cursor++
+		}
+	case repeatingKeyPressed(ebiten.KeyHome):
+		tf.cursor = 0
+	case repeatingKeyPressed(ebiten.KeyEnd):
+		tf.cursor = len(tf.value)
+	case repeatingKeyPressed(ebiten.KeyBackspace):
+		tf.deleteBeforeCursor()
+	case repeatingKeyPressed(ebiten.KeyDelete):
+		tf.deleteAfterCursor()
+	case repeatingKeyPressed(ebiten.KeyEnter):
+		if tf.multiline && !ebiten.IsKeyPressed(ebiten.KeyControl) {
+			tf.insert([]rune{'\n'})
+		} else if tf.onSubmit != nil {
+			tf.onSubmit(string(tf.value))
+		}
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		tf.selEnd = tf.cursor
+	} else {
+		tf.selStart, tf.selEnd = tf.cursor, tf.cursor
+	}
+}
+
+func (tf *TextField) deleteBeforeCursor() {
+	if tf.deleteSelection() {
+		if tf.onChange != nil {
+			tf.onChange(string(tf.value))
+		}
+		return
+	}
+
+	if tf.cursor == 0 {
+		return
+	}
+	tf.value = append(tf.value[:tf.cursor-1], tf.value[tf.cursor:]...)
+	tf.cursor--
+	if tf.onChange != nil {
+		tf.onChange(string(tf.value))
+	}
+}
+
+func (tf *TextField) deleteAfterCursor() {
+	if tf.deleteSelection() {
+		if tf.onChange != nil {
+			tf.onChange(string(tf.value))
+		}
+		return
+	}
+
+	if tf.cursor >= len(tf.value) {
+		return
+	}
+	tf.value = append(tf.value[:tf.cursor], tf.value[tf.cursor+1:]...)
+	if tf.onChange != nil {
+		tf.onChange(string(tf.value))
+	}
+}
+
+func (tf *TextField) selection() (int, int) {
+	if tf.selStart == tf.selEnd {
+		return -1, -1
+	}
+	if tf.selStart < tf.selEnd {
+		return tf.selStart, tf.selEnd
+	}
+	return tf.selEnd, tf.selStart
+}
+
+func (tf *TextField) handleClipboard() {
+	if !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		return
+	}
+
+	if !ensureClipboard() {
+		return
+	}
+
+	start, end := tf.selection()
+
+	if inpututilKeyJustPressed(ebiten.KeyC) && start >= 0 {
+		clipboard.Write(clipboard.FmtText, []byte(string(tf.value[start:end])))
+	}
+
+	if inpututilKeyJustPressed(ebiten.KeyX) && start >= 0 {
+		clipboard.Write(clipboard.FmtText, []byte(string(tf.value[start:end])))
+		tf.value = append(tf.value[:start], tf.value[end:]...)
+		tf.cursor = start
+		tf.selStart, tf.selEnd = tf.cursor, tf.cursor
+		if tf.onChange != nil {
+			tf.onChange(string(tf.value))
+		}
+	}
+
+	if inpututilKeyJustPressed(ebiten.KeyV) {
+		pasted := strings.ReplaceAll(string(clipboard.Read(clipboard.FmtText)), "\r\n", "\n")
+		if !tf.multiline {
+			pasted = strings.ReplaceAll(pasted, "\n", " ")
+		}
+		tf.insert([]rune(pasted))
+	}
+}
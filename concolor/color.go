@@ -2,8 +2,9 @@
 package concolor
 
 import (
+	"encoding/json"
 	"errors"
-	//"fmt"
+	"fmt"
 	//"strconv"
 )
 
@@ -27,7 +28,7 @@ func RGBA(r, g, b, a byte) Color {
 
 func Hex(hex string) (Color, error) {
 	c := Color{}
-	err := errors.New("")
+	var err error
 	c.A = 0xff
 
 	if hex[0] != '#' {
@@ -48,6 +49,11 @@ func Hex(hex string) (Color, error) {
 	}
 
 	switch len(hex) {
+	case 9:
+		c.R = hexToByte(hex[1])<<4 + hexToByte(hex[2])
+		c.G = hexToByte(hex[3])<<4 + hexToByte(hex[4])
+		c.B = hexToByte(hex[5])<<4 + hexToByte(hex[6])
+		c.A = hexToByte(hex[7])<<4 + hexToByte(hex[8])
 	case 7:
 		c.R = hexToByte(hex[1])<<4 + hexToByte(hex[2])
 		c.G = hexToByte(hex[3])<<4 + hexToByte(hex[4])
@@ -62,6 +68,55 @@ func Hex(hex string) (Color, error) {
 	return c, err
 }
 
+// HexString returns the color encoded as a "#rrggbbaa" hex string.
+func (c Color) HexString() string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// MarshalJSON encodes the color as its HexString, which keeps exported
+// buffers and palettes stable and human-readable across ramen versions.
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.HexString())
+}
+
+// UnmarshalJSON decodes a color previously encoded with MarshalJSON.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	col, err := Hex(s)
+	if err != nil {
+		return err
+	}
+
+	*c = col
+	return nil
+}
+
+// MarshalYAML encodes the color as its HexString, so a palette round-trips
+// the same "#rrggbbaa" form through YAML as it does through JSON.
+func (c Color) MarshalYAML() (interface{}, error) {
+	return c.HexString(), nil
+}
+
+// UnmarshalYAML decodes a color previously encoded with MarshalYAML.
+func (c *Color) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	col, err := Hex(s)
+	if err != nil {
+		return err
+	}
+
+	*c = col
+	return nil
+}
+
 
 // Hex creates a new color from a hex string
 /*
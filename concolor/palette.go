@@ -0,0 +1,100 @@
+package concolor
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Palette is a named map of role to Color, e.g. "background", "foreground",
+// "accent", "error", plus any user-defined keys. Attaching a palette to a
+// console lets inline color tags like [[f:$accent]] resolve against it, so a
+// whole game can be restyled without touching any Print calls.
+type Palette struct {
+	Name   string
+	Colors map[string]Color
+}
+
+// NewPalette creates an empty, named palette.
+func NewPalette(name string) *Palette {
+	return &Palette{Name: name, Colors: map[string]Color{}}
+}
+
+// Get looks up a named color. The second return value is false if name isn't
+// present in the palette, or if p is nil.
+func (p *Palette) Get(name string) (Color, bool) {
+	if p == nil {
+		return Color{}, false
+	}
+	col, ok := p.Colors[name]
+	return col, ok
+}
+
+// Set assigns a color to a role name. If p has no Colors map yet, one is
+// created.
+func (p *Palette) Set(name string, color Color) {
+	if p.Colors == nil {
+		p.Colors = map[string]Color{}
+	}
+	p.Colors[name] = color
+}
+
+// LoadPaletteJSON decodes a palette from JSON.
+func LoadPaletteJSON(data []byte) (*Palette, error) {
+	var p Palette
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadPaletteYAML decodes a palette from YAML.
+func LoadPaletteYAML(data []byte) (*Palette, error) {
+	var p Palette
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Interpolate returns a new palette that linearly blends every color role p
+// and other have in common, with t=0 returning p's colors and t=1 returning
+// other's. Roles only present in one palette are carried over unchanged, so
+// it is safe to animate between palettes that don't share every key.
+func (p *Palette) Interpolate(other *Palette, t float64) *Palette {
+	var name string
+	if p != nil {
+		name = p.Name
+	}
+	out := NewPalette(name)
+
+	if p != nil {
+		for name, col := range p.Colors {
+			out.Colors[name] = col
+		}
+	}
+
+	if other != nil {
+		for name, col := range other.Colors {
+			if from, ok := out.Colors[name]; ok {
+				out.Colors[name] = lerpColor(from, col, t)
+			} else {
+				out.Colors[name] = col
+			}
+		}
+	}
+
+	return out
+}
+
+func lerpColor(a, b Color, t float64) Color {
+	lerp := func(x, y byte) byte {
+		return byte(float64(x) + (float64(y)-float64(x))*t)
+	}
+	return Color{
+		R: lerp(a.R, b.R),
+		G: lerp(a.G, b.G),
+		B: lerp(a.B, b.B),
+		A: lerp(a.A, b.A),
+	}
+}
@@ -0,0 +1,45 @@
+package concolor
+
+// Solarized is the dark variant of Ethan Schoonover's Solarized palette.
+var Solarized = &Palette{
+	Name: "solarized",
+	Colors: map[string]Color{
+		"background": MustHex("#002b36"),
+		"foreground": MustHex("#839496"),
+		"accent":     MustHex("#268bd2"),
+		"error":      MustHex("#dc322f"),
+	},
+}
+
+// Gruvbox is the dark variant of the Gruvbox palette.
+var Gruvbox = &Palette{
+	Name: "gruvbox",
+	Colors: map[string]Color{
+		"background": MustHex("#282828"),
+		"foreground": MustHex("#ebdbb2"),
+		"accent":     MustHex("#fabd2f"),
+		"error":      MustHex("#fb4934"),
+	},
+}
+
+// Dracula is the Dracula palette.
+var Dracula = &Palette{
+	Name: "dracula",
+	Colors: map[string]Color{
+		"background": MustHex("#282a36"),
+		"foreground": MustHex("#f8f8f2"),
+		"accent":     MustHex("#bd93f9"),
+		"error":      MustHex("#ff5555"),
+	},
+}
+
+// Nord is the Nord palette.
+var Nord = &Palette{
+	Name: "nord",
+	Colors: map[string]Color{
+		"background": MustHex("#2e3440"),
+		"foreground": MustHex("#d8dee9"),
+		"accent":     MustHex("#88c0d0"),
+		"error":      MustHex("#bf616a"),
+	},
+}
@@ -0,0 +1,68 @@
+package console
+
+import "github.com/BigJk/ramen/concolor"
+
+// ansiBase16 are the standard 16 ANSI colors (30-37/90-97 for foreground,
+// 40-47/100-107 for background), in palette order black, red, green, yellow,
+// blue, magenta, cyan, white followed by their bright variants.
+var ansiBase16 = [16]concolor.Color{
+	concolor.RGB(0, 0, 0),
+	concolor.RGB(205, 0, 0),
+	concolor.RGB(0, 205, 0),
+	concolor.RGB(205, 205, 0),
+	concolor.RGB(0, 0, 238),
+	concolor.RGB(205, 0, 205),
+	concolor.RGB(0, 205, 205),
+	concolor.RGB(229, 229, 229),
+	concolor.RGB(127, 127, 127),
+	concolor.RGB(255, 0, 0),
+	concolor.RGB(0, 255, 0),
+	concolor.RGB(255, 255, 0),
+	concolor.RGB(92, 92, 255),
+	concolor.RGB(255, 0, 255),
+	concolor.RGB(0, 255, 255),
+	concolor.RGB(255, 255, 255),
+}
+
+// ansi256 is the full xterm 256-color palette: the 16 base colors, a 6x6x6
+// color cube and a 24-step grayscale ramp, built once at package init.
+var ansi256 = buildANSI256()
+
+// ansiCubeSteps are the intensity values each of the 6 steps per channel of
+// the 256-color cube map to, matching the standard xterm palette.
+var ansiCubeSteps = [6]byte{0, 95, 135, 175, 215, 255}
+
+func buildANSI256() [256]concolor.Color {
+	var palette [256]concolor.Color
+
+	copy(palette[:16], ansiBase16[:])
+
+	idx := 16
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				palette[idx] = concolor.RGB(ansiCubeSteps[r], ansiCubeSteps[g], ansiCubeSteps[b])
+				idx++
+			}
+		}
+	}
+
+	for i := 0; i < 24; i++ {
+		level := byte(8 + i*10)
+		palette[idx] = concolor.RGB(level, level, level)
+		idx++
+	}
+
+	return palette
+}
+
+// ansiColor256 looks up a color from the 256-color palette, clamping out of
+// range indices to the first or last entry.
+func ansiColor256(n int) concolor.Color {
+	if n < 0 {
+		n = 0
+	} else if n > 255 {
+		n = 255
+	}
+	return ansi256[n]
+}
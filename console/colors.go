@@ -0,0 +1,230 @@
+package console
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BigJk/ramen/concolor"
+	"github.com/BigJk/ramen/t"
+)
+
+// colorValueRegex matches either a hex color (#rrggbb, #rgb) or a reference
+// into the console's attached concolor.Palette ($name).
+const colorValueRegex = `(#[0-9a-zA-Z]+|\$[A-Za-z_][A-Za-z0-9_]*)`
+
+// colorTagRegex matches the inline color syntax, e.g. [[f:#ff0000]],
+// [[b:#000]], [[f:$accent]] or [[f:#ff0000|b:$background]].
+var colorTagRegex = regexp.MustCompile(`\[\[(([bf]):` + colorValueRegex + `)(\|(([bf]):` + colorValueRegex + `))?\]\]`)
+
+// ansiSGRRegex matches a single ANSI "Select Graphic Rendition" escape
+// sequence, e.g. "\x1b[31m" or "\x1b[38;5;202m".
+var ansiSGRRegex = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// colorSpan is a foreground/background override that takes effect from pos
+// (a byte offset into the cleaned text) onward, until a later span overrides
+// the same channel.
+type colorSpan struct {
+	pos int
+	fg  *concolor.Color
+	bg  *concolor.Color
+}
+
+// coloredText is returned by ParseColoredText alongside the cleaned text. It
+// lets PrintBoundedOffset look up, for any byte offset of the cleaned text,
+// the color transformers that should apply to it.
+type coloredText struct {
+	spans []colorSpan
+}
+
+// GetCurrentTransformer returns the t.Transformer's active at byte offset i
+// of the cleaned text.
+func (c coloredText) GetCurrentTransformer(i int) []t.Transformer {
+	var fg, bg *concolor.Color
+	for _, s := range c.spans {
+		if s.pos > i {
+			break
+		}
+		if s.fg != nil {
+			fg = s.fg
+		}
+		if s.bg != nil {
+			bg = s.bg
+		}
+	}
+
+	var trans []t.Transformer
+	if fg != nil {
+		trans = append(trans, t.FG(*fg))
+	}
+	if bg != nil {
+		trans = append(trans, t.BG(*bg))
+	}
+	return trans
+}
+
+// ParseColoredText strips the inline [[f:#hex|b:#hex]] color syntax and any
+// ANSI SGR escape sequences (\x1b[<params>m) from text and returns the
+// cleaned text alongside the color spans needed to reconstruct the original
+// styling. Both syntaxes can be mixed freely; spans apply in text order, so a
+// later one overrides an earlier one for the channel it sets, matching how a
+// terminal would apply them. A [[f:$name]]/[[b:$name]] reference is resolved against
+// palette; if palette is nil or doesn't contain name, the reference is
+// dropped.
+func ParseColoredText(text string, palette *concolor.Palette) (string, coloredText) {
+	var cleaned strings.Builder
+	var spans []colorSpan
+
+	for len(text) > 0 {
+		tagLoc := colorTagRegex.FindStringSubmatchIndex(text)
+		ansiLoc := ansiSGRRegex.FindStringSubmatchIndex(text)
+
+		// Pick whichever match starts first; process it and continue from
+		// after the match. If neither matches, the remaining text has no
+		// more styling and can be copied verbatim.
+		if tagLoc == nil && ansiLoc == nil {
+			cleaned.WriteString(text)
+			break
+		}
+
+		if ansiLoc == nil || (tagLoc != nil && tagLoc[0] <= ansiLoc[0]) {
+			cleaned.WriteString(text[:tagLoc[0]])
+			spans = append(spans, parseColorTag(text, tagLoc, cleaned.Len(), palette))
+			text = text[tagLoc[1]:]
+		} else {
+			cleaned.WriteString(text[:ansiLoc[0]])
+			spans = append(spans, parseANSISGR(text[ansiLoc[2]:ansiLoc[3]], cleaned.Len()))
+			text = text[ansiLoc[1]:]
+		}
+	}
+
+	return cleaned.String(), coloredText{spans: spans}
+}
+
+// parseColorTag turns a colorTagRegex submatch into a colorSpan at pos.
+func parseColorTag(text string, loc []int, pos int, palette *concolor.Palette) colorSpan {
+	span := colorSpan{pos: pos}
+
+	apply := func(kind, value string) {
+		col, ok := resolveColorValue(value, palette)
+		if !ok {
+			return
+		}
+		switch kind {
+		case "f":
+			span.fg = &col
+		case "b":
+			span.bg = &col
+		}
+	}
+
+	apply(text[loc[4]:loc[5]], text[loc[6]:loc[7]])
+	if loc[12] != -1 {
+		apply(text[loc[12]:loc[13]], text[loc[14]:loc[15]])
+	}
+
+	return span
+}
+
+// resolveColorValue turns either a "#hex" literal or a "$name" palette
+// reference into a concolor.Color.
+func resolveColorValue(value string, palette *concolor.Palette) (concolor.Color, bool) {
+	if strings.HasPrefix(value, "$") {
+		return palette.Get(value[1:])
+	}
+
+	col, err := concolor.Hex(value)
+	if err != nil {
+		return concolor.Color{}, false
+	}
+	return col, true
+}
+
+// parseANSISGR turns the semicolon-separated parameter list of a single SGR
+// escape sequence into a colorSpan at pos.
+func parseANSISGR(params string, pos int) colorSpan {
+	span := colorSpan{pos: pos}
+
+	if params == "" {
+		params = "0"
+	}
+
+	parts := strings.Split(params, ";")
+	defaultFg := concolor.RGB(255, 255, 255)
+	transparentBg := concolor.RGBA(0, 0, 0, 0)
+
+	for i := 0; i < len(parts); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case n == 0:
+			span.fg = defaultFg.P()
+			span.bg = transparentBg.P()
+		case n >= 30 && n <= 37:
+			span.fg = ansiBase16[n-30].P()
+		case n >= 90 && n <= 97:
+			span.fg = ansiBase16[8+n-90].P()
+		case n >= 40 && n <= 47:
+			span.bg = ansiBase16[n-40].P()
+		case n >= 100 && n <= 107:
+			span.bg = ansiBase16[8+n-100].P()
+		case n == 38 || n == 48:
+			col, consumed := parseExtendedColor(parts[i+1:])
+			i += consumed
+			if col == nil {
+				continue
+			}
+			if n == 38 {
+				span.fg = col
+			} else {
+				span.bg = col
+			}
+		}
+	}
+
+	return span
+}
+
+// parseExtendedColor parses the "5;N" (256-color) or "2;R;G;B" (truecolor)
+// forms that follow a 38 or 48 SGR parameter, returning the color and how
+// many of the following parameters it consumed.
+func parseExtendedColor(parts []string) (*concolor.Color, int) {
+	if len(parts) == 0 {
+		return nil, 0
+	}
+
+	mode, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, 0
+	}
+
+	switch mode {
+	case 5:
+		if len(parts) < 2 {
+			return nil, 1
+		}
+		n, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, 1
+		}
+		col := ansiColor256(n)
+		return col.P(), 2
+	case 2:
+		if len(parts) < 4 {
+			return nil, len(parts)
+		}
+		r, errR := strconv.Atoi(parts[1])
+		g, errG := strconv.Atoi(parts[2])
+		b, errB := strconv.Atoi(parts[3])
+		if errR != nil || errG != nil || errB != nil {
+			return nil, 4
+		}
+		col := concolor.RGB(byte(r), byte(g), byte(b))
+		return col.P(), 4
+	}
+
+	return nil, 0
+}
@@ -0,0 +1,40 @@
+package console
+
+// Component is a drawable, updatable widget that can be mounted onto a
+// console with Console.AddComponent. Update and Draw take a Renderer rather
+// than a concrete *Console so components can be driven identically by a real
+// Console or a headless Simulation.
+type Component interface {
+	// ID returns the unique identifier Console/Simulation use to track this component.
+	ID() string
+
+	// ShouldDraw reports whether the component should still be drawn and updated.
+	ShouldDraw() bool
+
+	// ShouldClose reports whether the component requested to be removed.
+	ShouldClose() bool
+
+	// FocusOnClick reports whether this component should receive focus when clicked.
+	FocusOnClick() bool
+
+	// SetFocus sets whether the component currently has keyboard focus.
+	SetFocus(focus bool)
+
+	// Position returns the top-left cell of the component.
+	Position() (int, int)
+
+	// Size returns the width and height of the component in cells.
+	Size() (int, int)
+
+	// Update advances the component's state by timeElapsed seconds. It
+	// returns false once the component wants to be removed.
+	Update(con Renderer, timeElapsed float64) bool
+
+	// Draw renders the component onto con.
+	Draw(con Renderer, timeElapsed float64)
+
+	// OnMouse is called for every mouse event whose cell falls within the
+	// component's Position/Size rectangle. Return true to consume the event
+	// and stop it from reaching consoles or handlers below this one.
+	OnMouse(event MouseEvent) bool
+}
@@ -6,7 +6,6 @@ import (
 	"math"
 	"strings"
 	"sync"
-  "regexp"
 	"sort"
 
 	"github.com/BigJk/ramen"
@@ -43,7 +42,23 @@ type Console struct {
 	mouseX int
 	mouseY int
 
+	palette *concolor.Palette
+
+	mouseHandler func(MouseEvent) bool
+	clock        float64
+	dragging     bool
+	dragButton   ebiten.MouseButton
+	dragStartX   int
+	dragStartY   int
+
+	hasPrevClick    bool
+	prevDownTime    float64
+	prevDownButton  ebiten.MouseButton
+	prevDownCellX   int
+	prevDownCellY   int
+
 	components map[string]Component
+	images     map[[2]int]*imageCell
 
 	tickHook       func(timeElapsed float64) error
 	preRenderHook  func(screen *ebiten.Image, timeElapsed float64) error
@@ -83,8 +98,14 @@ func (c *Console) Update() error {
 	mx, my := ebiten.CursorPosition()
 	c.propagateMousePosition(mx/c.Font.TileWidth, my/c.Font.TileHeight)
 	c.propagateComponentUpdates(c.elapsedTPS())
+	dispatches := c.collectMouseEvents(c.elapsedTPS())
 	c.mtx.RUnlock()
 
+	// Mouse handlers and component OnMouse callbacks run here, after
+	// RUnlock, since a handler calling Transform/Print/Clear takes
+	// c.mtx.Lock() and sync.RWMutex isn't reentrant.
+	_ = replayMouseDispatch(dispatches)
+
 	if c.tickHook != nil {
 		if err := c.tickHook(c.elapsedTPS()); err != nil {
 			return err
@@ -148,6 +169,12 @@ func (c *Console) SetPostRenderHook(hook func(screen *ebiten.Image, timeElapsed
 	return nil
 }
 
+// SetPalette attaches a palette so [[f:$name]]/[[b:$name]] color tags resolve
+// against it. Pass nil to detach the current palette.
+func (c *Console) SetPalette(palette *concolor.Palette) {
+	c.palette = palette
+}
+
 // SetPriority sets the priority of the console. A higher priority will result in the console
 // being drawn on top of all the ones with lower priority.
 func (c *Console) SetPriority(priority int) error {
@@ -277,6 +304,8 @@ func (c *Console) TransformArea(x, y, width, height int, transformer ...t.Transf
 					}
 				}
 			}
+
+			c.clearImageAt(px+x, py+y)
 		}
 	}
 
@@ -302,14 +331,15 @@ func (c *Console) Transform(x, y int, transformer ...t.Transformer) error {
 		}
 	}
 
+	c.clearImageAt(x, y)
+
 	return nil
 }
 
 // PrintCtrAdj prints text onto the console but automatically centre adjusted to the length of the text.
 func (c *Console) PrintCtrAdj(x, y int, text string, transformer ...t.Transformer) {
-  var colorSectionRegex = regexp.MustCompile("\\[\\[(([bf]):(#[0-9a-zA-Z]+))(\\|(([bf]):(#[0-9a-zA-Z]+)))?\\]\\]")
 	split := strings.Split(text,"\n")
-  matches := colorSectionRegex.FindAllStringIndex(split[0], -1)
+  matches := colorTagRegex.FindAllStringIndex(split[0], -1)
 	x = x - (len(split[0])/2)
   x = x + (len(matches)*7)
 	c.PrintBounded(x, y, 0, 0, text, transformer...)
@@ -336,7 +366,7 @@ func (c *Console) PrintBounded(x, y, width, height int, text string, transformer
 // To give the text a different foreground or background color use transformer.
 // This function also supports inlined color definitions.
 func (c *Console) PrintBoundedOffset(x, y, width, height, sy int, text string, transformer ...t.Transformer) int {
-	cleaned, colors := ParseColoredText(text)
+	cleaned, colors := ParseColoredText(text, c.palette)
 
 	line := 0
 	linePos := 0
@@ -371,7 +401,7 @@ func (c *Console) PrintBoundedOffset(x, y, width, height, sy int, text string, t
 
 // CalcTextHeight pre-calculates the height a text will need.
 func (c *Console) CalcTextHeight(width, height int, text string) int {
-	cleaned, _ := ParseColoredText(text)
+	cleaned, _ := ParseColoredText(text, c.palette)
 
 	line := 0
 	linePos := 0
@@ -438,7 +468,13 @@ func (c *Console) draw(screen *ebiten.Image, timeElapsed float64, offsetX, offse
 			ebitenutil.DrawRect(screen, float64((offsetX+c.x+x)*c.Font.TileWidth), float64((offsetY+c.y+y)*c.Font.TileHeight), float64(c.Font.TileWidth), float64(c.Font.TileHeight), c.buffer[x][y].Background)
 		}
 	}
+	c.mtx.RUnlock()
 
+	// drawImages takes c.mtx.RLock itself, so it must run outside the region
+	// above or a recursive RLock would deadlock.
+	c.drawImages(screen, offsetX, offsetY, ImageBehindGlyphs)
+
+	c.mtx.RLock()
 	for x := range c.buffer {
 		for y := range c.buffer[x] {
 			charImage := c.Font.ToSubImage(c.buffer[x][y].Char)
@@ -454,6 +490,8 @@ func (c *Console) draw(screen *ebiten.Image, timeElapsed float64, offsetX, offse
 	}
 	c.mtx.RUnlock()
 
+	c.drawImages(screen, offsetX, offsetY, ImageAboveGlyphs)
+
 	for i := range c.SubConsoles {
 		c.SubConsoles[i].draw(screen, timeElapsed, offsetX+c.x, offsetY+c.y)
 	}
@@ -0,0 +1,100 @@
+package console
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"os"
+
+	"github.com/BigJk/ramen"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Screenshot renders the full console, including sub-consoles, to an
+// offscreen image at native tile resolution.
+func (c *Console) Screenshot() (*ebiten.Image, error) {
+	if c.isSubConsole {
+		return nil, fmt.Errorf("only the main console can be screenshotted")
+	}
+
+	img := ebiten.NewImage(c.Width*c.Font.TileWidth, c.Height*c.Font.TileHeight)
+	c.draw(img, 0, 0, 0)
+	return img, nil
+}
+
+// SavePNG renders the console with Screenshot and writes the result to path as a PNG file.
+func (c *Console) SavePNG(path string) error {
+	img, err := c.Screenshot()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return png.Encode(f, img)
+}
+
+// ExportBuffer serializes this console's cell grid into a ramen.BufferSnapshot.
+func (c *Console) ExportBuffer() ramen.BufferSnapshot {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	cells := make([][]ramen.Cell, len(c.buffer))
+	for x := range c.buffer {
+		cells[x] = make([]ramen.Cell, len(c.buffer[x]))
+		copy(cells[x], c.buffer[x])
+	}
+
+	return ramen.BufferSnapshot{Width: c.Width, Height: c.Height, Cells: cells}
+}
+
+// ImportBuffer restores a previously exported ramen.BufferSnapshot into this console.
+// The snapshot's dimensions must match the console's.
+func (c *Console) ImportBuffer(snap ramen.BufferSnapshot) error {
+	if snap.Width != c.Width || snap.Height != c.Height {
+		return fmt.Errorf("snapshot size %dx%d does not match console size %dx%d", snap.Width, snap.Height, c.Width, c.Height)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for x := range snap.Cells {
+		copy(c.buffer[x], snap.Cells[x])
+	}
+
+	return nil
+}
+
+// EncodeJSON encodes a buffer snapshot as JSON.
+func EncodeJSON(snap ramen.BufferSnapshot) ([]byte, error) {
+	return json.Marshal(snap)
+}
+
+// DecodeJSON decodes a buffer snapshot previously produced by EncodeJSON.
+func DecodeJSON(data []byte) (ramen.BufferSnapshot, error) {
+	var snap ramen.BufferSnapshot
+	err := json.Unmarshal(data, &snap)
+	return snap, err
+}
+
+// EncodeGob encodes a buffer snapshot with encoding/gob, which is more compact than JSON.
+func EncodeGob(snap ramen.BufferSnapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(snap); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeGob decodes a buffer snapshot previously produced by EncodeGob.
+func DecodeGob(data []byte) (ramen.BufferSnapshot, error) {
+	var snap ramen.BufferSnapshot
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snap)
+	return snap, err
+}
@@ -0,0 +1,102 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ImageLayer controls whether an image cell is drawn before or after the
+// glyph layer, letting callers either paint a backdrop behind text or an
+// overlay on top of it.
+type ImageLayer int
+
+const (
+	// ImageBehindGlyphs draws the image after background rects but before glyphs.
+	ImageBehindGlyphs ImageLayer = iota
+	// ImageAboveGlyphs draws the image after glyphs, on top of everything else.
+	ImageAboveGlyphs
+)
+
+// ImageCellOptions controls how DrawImage places an image into the console.
+type ImageCellOptions struct {
+	// Layer controls draw order relative to the glyph layer.
+	Layer ImageLayer
+	// SpanWidth and SpanHeight let the image cover more than a single cell.
+	// Both default to 1 when <= 0.
+	SpanWidth  int
+	SpanHeight int
+}
+
+// imageCell is the bookkeeping DrawImage stores per top-left cell.
+type imageCell struct {
+	img  *ebiten.Image
+	opts ImageCellOptions
+}
+
+// DrawImage places img into the console starting at cell (x, y), scaled to
+// fill SpanWidth*TileWidth by SpanHeight*TileHeight pixels. The image bypasses
+// the font atlas entirely, which makes it suitable for embedding arbitrary
+// graphics (icons, portraits, sixel output, ...) into an otherwise
+// glyph-based console. Clearing the covered cells via Clear or TransformArea
+// removes the image reference again.
+func (c *Console) DrawImage(x, y int, img *ebiten.Image, opts ImageCellOptions) error {
+	if opts.SpanWidth <= 0 {
+		opts.SpanWidth = 1
+	}
+	if opts.SpanHeight <= 0 {
+		opts.SpanHeight = 1
+	}
+
+	if err := c.checkOutOfBounds(x, y); err != nil {
+		return err
+	}
+	if x+opts.SpanWidth > c.Width || y+opts.SpanHeight > c.Height {
+		return fmt.Errorf("image is out of bounds")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.images == nil {
+		c.images = map[[2]int]*imageCell{}
+	}
+	c.images[[2]int{x, y}] = &imageCell{img: img, opts: opts}
+
+	return nil
+}
+
+// clearImageAt drops any image cell whose span covers (x, y), not just one
+// stored at exactly (x, y), so clearing a single cell of a multi-cell image
+// removes the whole image reference. Callers must hold c.mtx.
+func (c *Console) clearImageAt(x, y int) {
+	for pos, cell := range c.images {
+		if x >= pos[0] && x < pos[0]+cell.opts.SpanWidth && y >= pos[1] && y < pos[1]+cell.opts.SpanHeight {
+			delete(c.images, pos)
+		}
+	}
+}
+
+// drawImages draws every image cell on the given layer.
+func (c *Console) drawImages(screen *ebiten.Image, offsetX, offsetY int, layer ImageLayer) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for pos, cell := range c.images {
+		if cell.opts.Layer != layer {
+			continue
+		}
+
+		dstW := cell.opts.SpanWidth * c.Font.TileWidth
+		dstH := cell.opts.SpanHeight * c.Font.TileHeight
+
+		srcBounds := cell.img.Bounds()
+		sx := float64(dstW) / float64(srcBounds.Dx())
+		sy := float64(dstH) / float64(srcBounds.Dy())
+
+		op := ebiten.DrawImageOptions{}
+		op.GeoM.Scale(sx, sy)
+		op.GeoM.Translate(float64((offsetX+c.x+pos[0])*c.Font.TileWidth), float64((offsetY+c.y+pos[1])*c.Font.TileHeight))
+		screen.DrawImage(cell.img, &op)
+	}
+}
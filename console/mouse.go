@@ -0,0 +1,193 @@
+package console
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// DoubleClickWindow is the maximum time between two clicks of the same
+// button, in the same cell, for them to be reported as a MouseDoubleClick.
+const DoubleClickWindow = 0.5
+
+// MouseEventType identifies what kind of mouse interaction a MouseEvent describes.
+type MouseEventType int
+
+const (
+	MouseMove MouseEventType = iota
+	MouseDown
+	MouseUp
+	MouseClick
+	MouseDoubleClick
+	MouseDrag
+	MouseWheel
+)
+
+// MouseEvent describes a single mouse interaction with a console, already
+// translated into the cell coordinates of the console it was dispatched to.
+type MouseEvent struct {
+	Type   MouseEventType
+	Button ebiten.MouseButton
+	X, Y   int
+
+	// StartX, StartY are only set for MouseDrag and mark the cell the drag started in.
+	StartX, StartY int
+
+	// WheelX, WheelY are only set for MouseWheel.
+	WheelX, WheelY float64
+}
+
+// SetMouseHandler registers a handler that is called for every mouse event
+// that reaches this console. Return true to mark the event as consumed,
+// which stops it from being handled by consoles below this one in priority.
+func (c *Console) SetMouseHandler(handler func(MouseEvent) bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.mouseHandler = handler
+}
+
+// mouseDispatch pairs a console with the events its own (not a sub-console's)
+// mouse interactions generated this tick.
+type mouseDispatch struct {
+	console *Console
+	events  []MouseEvent
+}
+
+// collectMouseEvents is called once per Update with the elapsed time since
+// the last tick. It walks the sub-console tree from highest to lowest
+// priority -- mirroring the order sortSubConsoles keeps draw in, just
+// reversed -- building the ordered list of per-console event batches
+// replayMouseDispatch should later emit: the top-most console at the cursor
+// first, so it gets the first chance to consume each event. It only reads
+// and updates Console bookkeeping (drag state, double-click tracking, the
+// tick clock); it never calls a component's OnMouse or a registered mouse
+// handler, so it's safe to call while holding c.mtx.
+func (c *Console) collectMouseEvents(timeElapsed float64) []mouseDispatch {
+	c.clock += timeElapsed
+
+	var dispatches []mouseDispatch
+	for i := len(c.SubConsoles) - 1; i >= 0; i-- {
+		sub := c.SubConsoles[i]
+		if sub.mouseX < 0 || sub.mouseY < 0 {
+			continue
+		}
+		dispatches = append(dispatches, sub.collectMouseEvents(timeElapsed)...)
+	}
+
+	if c.mouseX < 0 || c.mouseY < 0 {
+		return dispatches
+	}
+
+	if events := c.collectOwnMouseEvents(); len(events) > 0 {
+		dispatches = append(dispatches, mouseDispatch{console: c, events: events})
+	}
+
+	return dispatches
+}
+
+// replayMouseDispatch emits every console's collected events via c.emit,
+// preserving the priority order collectMouseEvents built them in: once any
+// console's events are consumed, consoles later in the list never see
+// theirs. Unlike collectMouseEvents, this calls component OnMouse handlers
+// and the registered mouse handler, so callers must not hold c.mtx while
+// calling it. Returns whether anything was consumed.
+func replayMouseDispatch(dispatches []mouseDispatch) bool {
+	for _, d := range dispatches {
+		consumed := false
+		for _, ev := range d.events {
+			if d.console.emit(ev) {
+				consumed = true
+			}
+		}
+		if consumed {
+			return true
+		}
+	}
+	return false
+}
+
+// collectOwnMouseEvents generates this console's own mouse events for the
+// current tick (i.e. not those of its sub-consoles) and updates drag/
+// double-click bookkeeping, without calling any handler.
+func (c *Console) collectOwnMouseEvents() []MouseEvent {
+	var events []MouseEvent
+
+	for _, button := range trackedMouseButtons {
+		if inpututil.IsMouseButtonJustPressed(button) {
+			events = append(events, MouseEvent{Type: MouseDown, Button: button, X: c.mouseX, Y: c.mouseY})
+			c.dragging = true
+			c.dragButton = button
+			c.dragStartX, c.dragStartY = c.mouseX, c.mouseY
+		}
+
+		if c.dragging && c.dragButton == button && ebiten.IsMouseButtonPressed(button) {
+			if c.mouseX != c.dragStartX || c.mouseY != c.dragStartY {
+				events = append(events, MouseEvent{Type: MouseDrag, Button: button, X: c.mouseX, Y: c.mouseY, StartX: c.dragStartX, StartY: c.dragStartY})
+			}
+		}
+
+		if inpututil.IsMouseButtonJustReleased(button) {
+			events = append(events, MouseEvent{Type: MouseUp, Button: button, X: c.mouseX, Y: c.mouseY})
+			c.dragging = false
+
+			if c.mouseX == c.dragStartX && c.mouseY == c.dragStartY {
+				events = append(events, MouseEvent{Type: MouseClick, Button: button, X: c.mouseX, Y: c.mouseY})
+
+				isDouble := c.hasPrevClick && button == c.prevDownButton &&
+					c.mouseX == c.prevDownCellX && c.mouseY == c.prevDownCellY &&
+					c.clock-c.prevDownTime <= DoubleClickWindow
+
+				if isDouble {
+					events = append(events, MouseEvent{Type: MouseDoubleClick, Button: button, X: c.mouseX, Y: c.mouseY})
+					c.hasPrevClick = false
+				} else {
+					c.prevDownTime = c.clock
+					c.prevDownButton = button
+					c.prevDownCellX, c.prevDownCellY = c.mouseX, c.mouseY
+					c.hasPrevClick = true
+				}
+			}
+		}
+	}
+
+	if wx, wy := ebiten.Wheel(); wx != 0 || wy != 0 {
+		events = append(events, MouseEvent{Type: MouseWheel, X: c.mouseX, Y: c.mouseY, WheelX: wx, WheelY: wy})
+	}
+
+	return events
+}
+
+// emit first gives any mounted component whose area covers ev a chance to
+// consume it via OnMouse, then falls back to the console's mouse handler.
+func (c *Console) emit(ev MouseEvent) bool {
+	if dispatchComponentMouse(c.components, ev) {
+		return true
+	}
+
+	if c.mouseHandler == nil {
+		return false
+	}
+	return c.mouseHandler(ev)
+}
+
+// dispatchComponentMouse calls OnMouse on every component in components
+// whose Position/Size rectangle contains ev, stopping at the first one that
+// consumes it. Shared by Console and Simulation.
+func dispatchComponentMouse(components map[string]Component, ev MouseEvent) bool {
+	for _, comp := range components {
+		x, y := comp.Position()
+		w, h := comp.Size()
+		if ev.X < x || ev.Y < y || ev.X >= x+w || ev.Y >= y+h {
+			continue
+		}
+		if comp.OnMouse(ev) {
+			return true
+		}
+	}
+	return false
+}
+
+var trackedMouseButtons = []ebiten.MouseButton{
+	ebiten.MouseButtonLeft,
+	ebiten.MouseButtonRight,
+	ebiten.MouseButtonMiddle,
+}
@@ -0,0 +1,44 @@
+package console
+
+import (
+	"github.com/BigJk/ramen/t"
+)
+
+// Renderer is the driver-independent surface that both Console and Simulation
+// implement. Components, sub-consoles and hooks that are only written against
+// Renderer can be driven by a real Ebiten window or by a headless Simulation
+// without any change, which makes them straightforward to unit test.
+type Renderer interface {
+	// Transform transforms a single cell.
+	Transform(x, y int, transformer ...t.Transformer) error
+
+	// TransformArea applies the given transformers to all cells in the given area.
+	TransformArea(x, y, width, height int, transformer ...t.Transformer) error
+
+	// TransformAll applies the given transformers to all cells.
+	TransformAll(transformer ...t.Transformer) error
+
+	// Clear clears part of the buffer.
+	Clear(x, y, width, height int) error
+
+	// ClearAll clears the whole buffer.
+	ClearAll() error
+
+	// Print prints text at the given position.
+	Print(x, y int, text string, transformer ...t.Transformer)
+
+	// PrintBounded prints text bounded by a width and height.
+	PrintBounded(x, y, width, height int, text string, transformer ...t.Transformer) int
+
+	// PrintBoundedOffset prints text bounded by a width and height, skipping the first sy lines.
+	PrintBoundedOffset(x, y, width, height, sy int, text string, transformer ...t.Transformer) int
+
+	// MousePosition returns the cell the mouse cursor is currently in.
+	MousePosition() (int, int)
+
+	// MouseInArea checks if the mouse cursor is currently in the given area.
+	MouseInArea(x, y, width, height int) bool
+}
+
+var _ Renderer = (*Console)(nil)
+var _ Renderer = (*Simulation)(nil)
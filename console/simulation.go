@@ -0,0 +1,346 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/BigJk/ramen"
+	"github.com/BigJk/ramen/concolor"
+	"github.com/BigJk/ramen/t"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Simulation is a headless Renderer backend. It maintains the same cell
+// buffer and mouse state as Console but never opens a window, which makes it
+// possible to unit test component behaviour and text/color parsing (Print,
+// PrintBounded, ...) without driving an Ebiten event loop. This mirrors the
+// approach tcell takes with its SimulationScreen.
+type Simulation struct {
+	Width  int
+	Height int
+
+	mtx    sync.RWMutex
+	buffer [][]ramen.Cell
+
+	mouseX int
+	mouseY int
+
+	components map[string]Component
+
+	mouseHandler func(MouseEvent) bool
+	clock        float64
+
+	hasPrevClick  bool
+	prevClick     ebiten.MouseButton
+	prevClickX    int
+	prevClickY    int
+	prevClickTime float64
+
+	lastClick    ebiten.MouseButton
+	hasLastClick bool
+
+	palette *concolor.Palette
+}
+
+// AddComponent adds a component that should be updated and rendered by the
+// simulation, mirroring Console.AddComponent.
+func (s *Simulation) AddComponent(component Component) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.components == nil {
+		s.components = map[string]Component{}
+	}
+	s.components[component.ID()] = component
+}
+
+// RemoveComponent removes a component from the simulation.
+func (s *Simulation) RemoveComponent(component Component) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.components, component.ID())
+}
+
+// SetMouseHandler registers a handler that is called for every mouse event
+// injected into this simulation, mirroring Console.SetMouseHandler.
+func (s *Simulation) SetMouseHandler(handler func(MouseEvent) bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.mouseHandler = handler
+}
+
+// SetPalette attaches a palette so [[f:$name]]/[[b:$name]] color tags resolve
+// against it. Pass nil to detach the current palette.
+func (s *Simulation) SetPalette(palette *concolor.Palette) {
+	s.palette = palette
+}
+
+// NewSimulation creates a new headless console simulation of the given size.
+func NewSimulation(width, height int) *Simulation {
+	buf := make([][]ramen.Cell, width)
+	for x := range buf {
+		buf[x] = make([]ramen.Cell, height)
+		for y := range buf[x] {
+			buf[x][y] = emptyCell
+		}
+	}
+
+	return &Simulation{
+		Width:  width,
+		Height: height,
+		buffer: buf,
+		mouseX: -1,
+		mouseY: -1,
+	}
+}
+
+// ClearAll clears the whole simulation.
+func (s *Simulation) ClearAll() error {
+	return s.TransformAll(t.Cell(emptyCell))
+}
+
+// Clear clears part of the simulation.
+func (s *Simulation) Clear(x, y, width, height int) error {
+	return s.TransformArea(x, y, width, height, t.Cell(emptyCell))
+}
+
+// TransformAll applies the given transformers to all cells in the simulation.
+func (s *Simulation) TransformAll(transformer ...t.Transformer) error {
+	return s.TransformArea(0, 0, s.Width, s.Height, transformer...)
+}
+
+// TransformArea applies the given transformers to all cells in the given area.
+func (s *Simulation) TransformArea(x, y, width, height int, transformer ...t.Transformer) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for px := 0; px < width; px++ {
+		for py := 0; py < height; py++ {
+			if err := s.checkOutOfBounds(px+x, py+y); err != nil {
+				return err
+			}
+
+			if len(transformer) == 0 {
+				s.buffer[px+x][py+y] = emptyCell
+			} else {
+				for i := range transformer {
+					if err := transformer[i].Transform(&s.buffer[px+x][py+y]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Transform transforms a single cell.
+func (s *Simulation) Transform(x, y int, transformer ...t.Transformer) error {
+	if len(transformer) == 0 {
+		return fmt.Errorf("no transformer given")
+	} else if err := s.checkOutOfBounds(x, y); err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for i := range transformer {
+		if err := transformer[i].Transform(&s.buffer[x][y]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Print prints a text into the simulation buffer. See Console.Print.
+func (s *Simulation) Print(x, y int, text string, transformer ...t.Transformer) {
+	s.PrintBounded(x, y, 0, 0, text, transformer...)
+}
+
+// PrintBounded prints a text into the simulation buffer bounded by a width and height. See Console.PrintBounded.
+func (s *Simulation) PrintBounded(x, y, width, height int, text string, transformer ...t.Transformer) int {
+	return s.PrintBoundedOffset(x, y, width, height, 0, text, transformer...)
+}
+
+// PrintBoundedOffset prints a text into the simulation buffer bounded by a width and height
+// and skips the first sy lines. See Console.PrintBoundedOffset.
+func (s *Simulation) PrintBoundedOffset(x, y, width, height, sy int, text string, transformer ...t.Transformer) int {
+	cleaned, colors := ParseColoredText(text, s.palette)
+
+	line := 0
+	linePos := 0
+	for i, val := range cleaned {
+		if cleaned[i] == '\n' || width > 0 && linePos >= width {
+			y++
+			linePos = 0
+			line++
+
+			if cleaned[i] == '\n' {
+				continue
+			}
+		}
+
+		if x+linePos >= s.Width || height > 0 && line >= height {
+			continue
+		}
+
+		if line >= sy {
+			trans := transformer
+			trans = append(trans, t.Char(int(val)))
+			trans = append(trans, colors.GetCurrentTransformer(i)...)
+
+			_ = s.Transform(linePos+x, y-sy, trans...)
+		}
+
+		linePos++
+	}
+
+	return line + 1 - sy
+}
+
+// MousePosition returns the cell the injected mouse cursor is currently in.
+func (s *Simulation) MousePosition() (int, int) {
+	return s.mouseX, s.mouseY
+}
+
+// MouseInArea checks if the injected mouse cursor is currently in the given area.
+func (s *Simulation) MouseInArea(x, y, width, height int) bool {
+	return s.mouseX >= x && s.mouseY >= y && s.mouseX < x+width && s.mouseY < y+height
+}
+
+// InjectMouse moves the simulated mouse cursor to the given cell, as if the
+// user had moved the real mouse there. Pass (-1, -1) to move it outside the
+// console.
+func (s *Simulation) InjectMouse(x, y int) {
+	s.mouseX = x
+	s.mouseY = y
+}
+
+// InjectClick simulates a full press-and-release of button at the current
+// mouse position, as Console.collectMouseEvents would synthesize from real
+// input: it emits MouseDown, MouseUp and MouseClick, followed by
+// MouseDoubleClick if it follows a prior click of the same button, in the
+// same cell, within DoubleClickWindow, running them through any mounted component's OnMouse
+// and the registered mouse handler.
+func (s *Simulation) InjectClick(button ebiten.MouseButton) {
+	s.lastClick = button
+	s.hasLastClick = true
+
+	if s.mouseX < 0 || s.mouseY < 0 {
+		return
+	}
+
+	s.emit(MouseEvent{Type: MouseDown, Button: button, X: s.mouseX, Y: s.mouseY})
+	s.emit(MouseEvent{Type: MouseUp, Button: button, X: s.mouseX, Y: s.mouseY})
+	s.emit(MouseEvent{Type: MouseClick, Button: button, X: s.mouseX, Y: s.mouseY})
+
+	isDouble := s.hasPrevClick && button == s.prevClick &&
+		s.mouseX == s.prevClickX && s.mouseY == s.prevClickY &&
+		s.clock-s.prevClickTime <= DoubleClickWindow
+
+	if isDouble {
+		s.emit(MouseEvent{Type: MouseDoubleClick, Button: button, X: s.mouseX, Y: s.mouseY})
+		s.hasPrevClick = false
+	} else {
+		s.prevClick = button
+		s.prevClickX, s.prevClickY = s.mouseX, s.mouseY
+		s.prevClickTime = s.clock
+		s.hasPrevClick = true
+	}
+}
+
+// LastClick returns the last button passed to InjectClick and whether a click
+// was injected at all.
+func (s *Simulation) LastClick() (ebiten.MouseButton, bool) {
+	return s.lastClick, s.hasLastClick
+}
+
+// emit runs any mounted component's OnMouse and then the simulation's mouse
+// handler for ev, mirroring Console.emit.
+func (s *Simulation) emit(ev MouseEvent) bool {
+	if dispatchComponentMouse(s.components, ev) {
+		return true
+	}
+
+	if s.mouseHandler == nil {
+		return false
+	}
+	return s.mouseHandler(ev)
+}
+
+// Tick advances the simulation by dt seconds: it moves the internal clock
+// used for double-click detection and drives every mounted component's
+// Update and Draw, the same way Console does once per Ebiten frame. This
+// lets tests exercise time-dependent component behaviour (blinking cursors,
+// animations, ...) without a real Ebiten event loop.
+func (s *Simulation) Tick(dt float64) error {
+	s.clock += dt
+
+	for id := range s.components {
+		comp := s.components[id]
+
+		if !comp.ShouldDraw() {
+			comp.SetFocus(false)
+		}
+
+		if comp.ShouldClose() || !comp.Update(s, dt) {
+			delete(s.components, id)
+			continue
+		}
+
+		if comp.ShouldDraw() {
+			comp.Draw(s, dt)
+		}
+	}
+
+	return nil
+}
+
+// Snapshot returns a copy of the current cell buffer, indexed [x][y].
+func (s *Simulation) Snapshot() [][]ramen.Cell {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	snap := make([][]ramen.Cell, len(s.buffer))
+	for x := range s.buffer {
+		snap[x] = make([]ramen.Cell, len(s.buffer[x]))
+		copy(snap[x], s.buffer[x])
+	}
+
+	return snap
+}
+
+// SnapshotString flattens the buffer's Char fields into one string per row,
+// joined by newlines, so tests can assert on rendered text with a plain
+// string comparison.
+func (s *Simulation) SnapshotString() string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	rows := make([]string, s.Height)
+	for y := 0; y < s.Height; y++ {
+		var sb strings.Builder
+		for x := 0; x < s.Width; x++ {
+			ch := rune(s.buffer[x][y].Char)
+			if ch == 0 {
+				ch = ' '
+			}
+			sb.WriteRune(ch)
+		}
+		rows[y] = sb.String()
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func (s *Simulation) checkOutOfBounds(x, y int) error {
+	if x < 0 || y < 0 || x >= s.Width || y >= s.Height {
+		return fmt.Errorf("position out of bounds")
+	}
+	return nil
+}
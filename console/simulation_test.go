@@ -0,0 +1,109 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen/concolor"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestSimulationPrintBoundedAndSnapshot(t *testing.T) {
+	sim := NewSimulation(10, 3)
+
+	sim.Print(0, 0, "[[f:#ff0000]]hi")
+
+	snap := sim.Snapshot()
+	if snap[0][0].Char != 'h' || snap[1][0].Char != 'i' {
+		t.Fatalf("expected 'hi' at the start of row 0, got %q", sim.SnapshotString())
+	}
+	if snap[0][0].Foreground != concolor.MustHex("#ff0000") {
+		t.Fatalf("expected the color tag to set the foreground, got %+v", snap[0][0].Foreground)
+	}
+}
+
+func TestSimulationMouseClickConsumedByComponent(t *testing.T) {
+	sim := NewSimulation(10, 5)
+
+	consumed := false
+	comp := &stubComponent{
+		id: "stub", x: 2, y: 2, w: 3, h: 1,
+		onMouse: func(ev MouseEvent) bool {
+			if ev.Type == MouseClick {
+				consumed = true
+				return true
+			}
+			return false
+		},
+	}
+	sim.AddComponent(comp)
+
+	handlerCalled := false
+	sim.SetMouseHandler(func(ev MouseEvent) bool {
+		if ev.Type == MouseClick {
+			handlerCalled = true
+		}
+		return false
+	})
+
+	sim.InjectMouse(3, 2)
+	sim.InjectClick(ebiten.MouseButtonLeft)
+
+	if !consumed {
+		t.Fatalf("expected the component's OnMouse to see the click")
+	}
+	if handlerCalled {
+		t.Fatalf("expected the component to consume the click before the console-level handler ran")
+	}
+}
+
+func TestSimulationDoubleClick(t *testing.T) {
+	sim := NewSimulation(5, 5)
+
+	var types []MouseEventType
+	sim.SetMouseHandler(func(ev MouseEvent) bool {
+		types = append(types, ev.Type)
+		return false
+	})
+
+	sim.InjectMouse(1, 1)
+	sim.InjectClick(ebiten.MouseButtonLeft)
+	_ = sim.Tick(0.01)
+	sim.InjectClick(ebiten.MouseButtonLeft)
+
+	foundDouble := false
+	for _, typ := range types {
+		if typ == MouseDoubleClick {
+			foundDouble = true
+		}
+	}
+	if !foundDouble {
+		t.Fatalf("expected a double click within the double click window, got %v", types)
+	}
+}
+
+type stubComponent struct {
+	id     string
+	x, y   int
+	w, h   int
+	closed bool
+
+	onMouse func(MouseEvent) bool
+}
+
+func (s *stubComponent) ID() string           { return s.id }
+func (s *stubComponent) ShouldDraw() bool     { return true }
+func (s *stubComponent) ShouldClose() bool    { return s.closed }
+func (s *stubComponent) FocusOnClick() bool   { return true }
+func (s *stubComponent) SetFocus(focus bool)  {}
+func (s *stubComponent) Position() (int, int) { return s.x, s.y }
+func (s *stubComponent) Size() (int, int)     { return s.w, s.h }
+func (s *stubComponent) Update(con Renderer, timeElapsed float64) bool {
+	return true
+}
+func (s *stubComponent) Draw(con Renderer, timeElapsed float64) {}
+func (s *stubComponent) OnMouse(ev MouseEvent) bool {
+	if s.onMouse == nil {
+		return false
+	}
+	return s.onMouse(ev)
+}
@@ -0,0 +1,198 @@
+package console
+
+import (
+	"bufio"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// LoadSixel decodes a DEC sixel image stream and returns it as an
+// *ebiten.Image, so output from tools that emit sixels can be placed into a
+// console with DrawImage. It supports the practical subset of the format:
+// color register definitions in the "2" (RGB percent) color space, "!"
+// repeat counts, "$" carriage return and "-" line feed. Raster attributes and
+// other DCS parameters are consumed but otherwise ignored; the canvas grows
+// to fit whatever is decoded.
+func LoadSixel(r io.Reader) (*ebiten.Image, error) {
+	br := bufio.NewReader(r)
+
+	// Skip to the start of the sixel data, which begins after the final 'q'
+	// of the DCS introducer (ESC P ... q).
+	if err := skipToSixelData(br); err != nil {
+		return nil, err
+	}
+
+	registers := map[int]color.RGBA{
+		0: {0, 0, 0, 255},
+	}
+
+	pixels := map[[2]int]color.RGBA{}
+	maxX, maxY := 0, 0
+
+	curColor := 0
+	x, y := 0, 0
+	repeat := 1
+
+	for {
+		ch, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case ch == '#':
+			id, err := readInt(br)
+			if err != nil {
+				return nil, err
+			}
+			curColor = id
+
+			if peekIsSemicolon(br) {
+				_, _ = br.ReadByte() // consume the ';' separating Pc from Pu;Px;Py;Pz
+
+				params, err := readParams(br)
+				if err != nil {
+					return nil, err
+				}
+				if len(params) >= 4 && params[0] == 2 {
+					registers[id] = color.RGBA{
+						R: percentToByte(params[1]),
+						G: percentToByte(params[2]),
+						B: percentToByte(params[3]),
+						A: 255,
+					}
+				}
+			}
+		case ch == '!':
+			n, err := readInt(br)
+			if err != nil {
+				return nil, err
+			}
+			repeat = n
+		case ch == '$':
+			x = 0
+			repeat = 1
+		case ch == '-':
+			x = 0
+			y += 6
+			repeat = 1
+		case ch >= '?' && ch <= '~':
+			bits := ch - '?'
+			col, ok := registers[curColor]
+			if !ok {
+				col = color.RGBA{255, 255, 255, 255}
+			}
+
+			for i := 0; i < repeat; i++ {
+				for bit := 0; bit < 6; bit++ {
+					if bits&(1<<uint(bit)) != 0 {
+						py := y + bit
+						pixels[[2]int{x, py}] = col
+						if x > maxX {
+							maxX = x
+						}
+						if py > maxY {
+							maxY = py
+						}
+					}
+				}
+				x++
+			}
+			repeat = 1
+		case ch == 0x1b || ch == 0x9c:
+			// ST (string terminator), either 7-bit (ESC \) or 8-bit (0x9c): done.
+			if ch == 0x1b {
+				_, _ = br.ReadByte() // consume the '\'
+			}
+			return toImage(pixels, maxX+1, maxY+1), nil
+		}
+	}
+
+	return toImage(pixels, maxX+1, maxY+1), nil
+}
+
+func skipToSixelData(br *bufio.Reader) error {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b == 'q' {
+			return nil
+		}
+	}
+}
+
+func peekIsSemicolon(br *bufio.Reader) bool {
+	b, err := br.Peek(1)
+	if err != nil || len(b) == 0 {
+		return false
+	}
+	return b[0] == ';'
+}
+
+func readInt(br *bufio.Reader) (int, error) {
+	var digits []byte
+	for {
+		b, err := br.Peek(1)
+		if err != nil || len(b) == 0 || b[0] < '0' || b[0] > '9' {
+			break
+		}
+		digits = append(digits, b[0])
+		_, _ = br.ReadByte()
+	}
+	if len(digits) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(string(digits))
+}
+
+func readParams(br *bufio.Reader) ([]int, error) {
+	var params []int
+	for {
+		n, err := readInt(br)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, n)
+
+		b, err := br.Peek(1)
+		if err != nil || len(b) == 0 || b[0] != ';' {
+			break
+		}
+		_, _ = br.ReadByte()
+	}
+	return params, nil
+}
+
+func percentToByte(p int) byte {
+	if p < 0 {
+		p = 0
+	} else if p > 100 {
+		p = 100
+	}
+	return byte(p * 255 / 100)
+}
+
+func toImage(pixels map[[2]int]color.RGBA, width, height int) *ebiten.Image {
+	if width <= 0 {
+		width = 1
+	}
+	if height <= 0 {
+		height = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for pos, col := range pixels {
+		img.SetRGBA(pos[0], pos[1], col)
+	}
+
+	return ebiten.NewImageFromImage(img)
+}
@@ -0,0 +1,12 @@
+package ramen
+
+// BufferSnapshot is a serializable copy of a console's cell grid, indexed
+// [x][y] like the console's own buffer. It is produced by
+// console.Console.ExportBuffer and restored with console.Console.ImportBuffer,
+// and can be encoded with console.EncodeJSON/DecodeJSON or
+// console.EncodeGob/DecodeGob for save games, replays or regression tests.
+type BufferSnapshot struct {
+	Width  int
+	Height int
+	Cells  [][]Cell
+}